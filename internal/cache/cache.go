@@ -1,76 +1,302 @@
 package cache
 
 import (
+	"container/heap"
 	"container/list"
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
-// Config controls cache capacity and maintenance behavior.
+// Config controls cache capacity, maintenance behavior, and (optionally) eviction
+// notification for the string/[]byte cache returned by New.
 //
 // Correctness-first defaults:
 //   - MaxEntries <= 0 means "unbounded" (no LRU eviction)
-//   - CleanupInterval <= 0 disables background cleanup (lazy expiration still works)
+//   - CleanupInterval <= 0 disables the background expiry goroutine entirely (lazy
+//     expiration on Get still works)
 //
-// Background cleanup exists to prevent memory growth when keys are written once and never read again.
+// Background expiry exists to prevent memory growth when keys are written once and never read again.
 // Lazy expiration alone can leave dead entries in memory indefinitely.
+//
+// CleanupInterval no longer sets a polling period: the background goroutine wakes
+// exactly when the next entry is due to expire (see expiryLoop), so any positive
+// value simply opts in to running it.
 type Config struct {
 	MaxEntries      int
 	CleanupInterval time.Duration
+
+	// Policy selects how a capacity-triggered eviction picks its victim. The zero
+	// value, PolicyLRU, preserves prior behavior.
+	Policy Policy
+
+	// MaxBytes, if > 0, bounds total cache size in addition to MaxEntries: eviction
+	// keeps popping the Policy-selected victim until both budgets are satisfied.
+	// MaxBytes <= 0 means "unbounded" (no byte-budget eviction), matching MaxEntries.
+	MaxBytes int64
+
+	// Sizer estimates an entry's cost against MaxBytes. If MaxBytes > 0 and Sizer is
+	// nil, it defaults to len(key)+len(value)+a fixed per-entry overhead.
+	Sizer func(key string, value []byte) int64
+
+	// OnEvicted, if set, is called whenever an entry leaves the cache — via explicit
+	// Delete, LRU/capacity eviction, or TTL expiration — with the reason it left. It's
+	// invoked outside the cache's mutex, so it's safe for OnEvicted to call back into
+	// the cache (e.g. re-Set the key) without deadlocking.
+	//
+	// OnEvicted is a convenience for the common single-observer case. For multiple
+	// independent observers (metrics, logging, invalidation all attaching at once),
+	// use Cache.OnEviction/OnInsertion instead, which support any number of
+	// subscribers without racing each other.
+	OnEvicted func(key string, value []byte, reason EvictionReason)
 }
 
+// TypedConfig is Config generalized to NewTyped's key and value types.
+type TypedConfig[K comparable, V any] struct {
+	MaxEntries      int
+	CleanupInterval time.Duration
+	Policy          Policy
+	MaxBytes        int64
+	Sizer           func(key K, value V) int64
+	OnEvicted       func(key K, value V, reason EvictionReason)
+}
+
+// Policy selects how the cache picks an eviction victim once MaxEntries or MaxBytes
+// is exceeded.
+// It has no effect on TTL-based expiration, which always reaps whatever is due
+// regardless of policy.
+type Policy int
+
+const (
+	// PolicyLRU evicts the least-recently-used entry. Get moves the accessed key to
+	// the front of the list, so eviction always removes from the back.
+	PolicyLRU Policy = iota
+
+	// PolicyFIFO evicts in strict insertion order, ignoring access recency: Get
+	// never reorders the list, so the back of the list is always the oldest
+	// surviving key.
+	PolicyFIFO
+
+	// PolicySIEVE evicts using the SIEVE algorithm (https://sievecache.com): each
+	// entry carries a single "visited" bit, set by Get and otherwise left alone (no
+	// list splicing on access, unlike PolicyLRU). Eviction sweeps a persistent hand
+	// from the tail toward the head: a visited entry has its bit cleared and the
+	// hand advances; an unvisited entry is evicted and the hand stops just past it.
+	// The hand wraps from the head back to the tail. This costs less per Get than
+	// LRU while matching or beating its hit ratio on many real-world workloads.
+	PolicySIEVE
+)
+
+// EvictionReason identifies why an entry left the cache, passed to OnEvicted and to
+// OnEviction subscribers.
+type EvictionReason int
+
+const (
+	// EvictionReasonDeleted means the caller removed the key explicitly via Delete.
+	EvictionReasonDeleted EvictionReason = iota
+	// EvictionReasonCapacityReached means the entry was the Policy-selected victim
+	// of a MaxEntries- or MaxBytes-triggered eviction.
+	EvictionReasonCapacityReached
+	// EvictionReasonExpired means the entry's TTL had passed, whether it was
+	// reclaimed lazily (on Get) or by the background expiry loop.
+	EvictionReasonExpired
+)
+
 // Cache is a concurrency-safe in-memory key–value cache with TTL and LRU eviction.
 //
+// Cache is a thin outer handle: all state and logic live in the inner cacheCore,
+// which the background expiry goroutine holds a reference to instead of Cache
+// itself. This lets Cache carry a runtime.SetFinalizer (see New/NewTyped) that stops
+// the goroutine if a caller drops the cache without calling Close — a Cache that
+// also owned the goroutine directly would never become unreachable while it ran,
+// since the goroutine's closure would keep it alive forever.
+//
+// Cache is generic over key and value types. Construct one with New (the
+// string/[]byte specialization, preserved for existing callers) or NewTyped (any
+// comparable K, any V).
+type Cache[K comparable, V any] struct {
+	core *cacheCore[K, V]
+}
+
+// cacheCore holds everything Cache forwards to.
+//
 // The core design is intentionally explicit and "mechanical":
 // a map gives O(1) key lookup, and a doubly-linked list maintains recency ordering.
-//
-// Ownership model:
-// Cache owns its internal goroutines. Call Close to stop them.
-type Cache struct {
+type cacheCore[K comparable, V any] struct {
 	mu sync.RWMutex
 
 	maxEntries int
-	items      map[string]*list.Element
-	lru        *list.List // Front = most recently used (MRU), Back = least recently used (LRU)
+	policy     Policy
+	items      map[K]*list.Element
+	lru        *list.List // Front = most recently inserted/used, Back = eviction candidate
+
+	// sieveHand is PolicySIEVE's persistent sweep pointer; nil means "start the next
+	// sweep at the tail". Unused by PolicyLRU/PolicyFIFO.
+	sieveHand *list.Element
+
+	// maxBytes and sizer implement the optional byte-budget eviction trigger, on top
+	// of maxEntries. maxBytes <= 0 disables it; sizer nil means Set sizes every entry
+	// 0, so only GetOrCompute's explicitly sized entries count against maxBytes.
+	maxBytes int64
+	sizer    func(key K, value V) int64
+
+	// Stats counters. All atomic so Stats() can read them without taking mu (each
+	// load is separate, so the counters aren't read as one unit); entries/usedBytes mirror len(items)/the byte budget's running total,
+	// updated at every point that already holds mu to mutate items.
+	hits                     atomic.Int64
+	misses                   atomic.Int64
+	insertions               atomic.Int64
+	evictionsDeleted         atomic.Int64
+	evictionsCapacityReached atomic.Int64
+	evictionsExpired         atomic.Int64
+	entries                  atomic.Int64
+	usedBytes                atomic.Int64
+
+	// flight serializes concurrent GetOrCompute misses for the same key so only one
+	// caller actually runs compute; the rest block and share its result.
+	flight singleflight.Group
+
+	// clone, when non-nil, is applied to values going in (Set) and out (Get) so the
+	// cache never retains or hands out a reference the caller could mutate behind its
+	// back. Only the []byte specialization returned by New sets this; NewTyped leaves
+	// it nil, trading that safety net for avoiding a copy on every Set/Get.
+	clone func(V) V
+
+	// onEvicted is the Config/TypedConfig single-observer hook. Always called before
+	// any subs.evicted subscribers.
+	onEvicted func(key K, value V, reason EvictionReason)
+	subs      subscriptions[K, V]
+
+	// pending queues eviction notifications staged while mu is held, so they can be
+	// fired from outside the lock (see takePendingLocked/firePending).
+	pending []pendingEviction[K, V]
+
+	// expQueue orders entries with hasExpiry==true by expiresAt, so expiryLoop can
+	// find (and popExpiredLocked can reap) the next entry due to expire in O(log n)
+	// instead of scanning every entry on every tick.
+	expQueue expirationQueue[K, V]
+
+	// timerCh signals expiryLoop that the heap's head may have moved earlier and its
+	// timer should be reset. Buffered so Set never blocks on a slow/busy loop;
+	// signalTimer drains a stale pending value before resending rather than blocking.
+	timerCh chan time.Duration
 
 	// Goroutine ownership.
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 
+	// loopExited is closed by expiryLoop's defer right before it returns. It exists
+	// purely so tests can observe the goroutine actually exiting (e.g. after the
+	// finalizer fires) without racing wg, which a white-box test can't Wait on
+	// without reaching into cacheCore itself.
+	loopExited chan struct{}
+
 	cleanupEvery time.Duration
 	closed       bool
 }
 
+// pendingEviction is a staged eviction notification: the entry is already unlinked
+// from items/lru, but the callback hasn't fired yet because mu is still held.
+type pendingEviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
 // entry is the value stored in the LRU list elements.
 // We keep the key here because eviction starts from list nodes.
 //
 // ExpiresAt is optional: hasExpiry=false means "never expires".
-type entry struct {
-	key       string
-	value     []byte
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
 	expiresAt time.Time
 	hasExpiry bool
+
+	// visited is PolicySIEVE's one-bit recency marker, set by Get and cleared by the
+	// sweeping hand in sieveVictimLocked. Unused by PolicyLRU/PolicyFIFO.
+	visited bool
+
+	// size is this entry's cost against maxBytes, as last computed by sizer (or
+	// supplied directly by GetOrCompute's compute). Zero if byte-budget eviction
+	// isn't in use.
+	size int64
+
+	// heapIndex is this entry's position in expQueue, maintained by
+	// expirationQueue.Swap/Push/Pop. -1 means "not a heap member" (hasExpiry==false,
+	// or it hasn't been pushed yet).
+	heapIndex int
 }
 
 var ErrClosed = errors.New("cache is closed")
 
-// New constructs a cache and starts background maintenance (if enabled).
+// New constructs a string-keyed, []byte-valued cache and starts background
+// maintenance (if enabled). It is the original, pre-generics API: Set copies the
+// value in and Get copies it out, so callers can't corrupt cache state by mutating a
+// slice they passed in or got back, nor can two Get calls observe each other's
+// mutations. Callers who don't need that (or who want to store something other than
+// []byte) should use NewTyped.
 //
 // New never returns a nil Cache.
-func New(cfg Config) *Cache {
+func New(cfg Config) *Cache[string, []byte] {
+	sizer := cfg.Sizer
+	if cfg.MaxBytes > 0 && sizer == nil {
+		sizer = defaultByteSizer
+	}
+	core := newCacheCore[string, []byte](cfg.MaxEntries, cfg.CleanupInterval, cfg.Policy, cfg.MaxBytes, sizer, cfg.OnEvicted)
+	core.clone = cloneBytes
+	return wrapCore(core)
+}
+
+// entryOverheadBytes approximates the per-entry bookkeeping cost (map bucket, list
+// element, struct fields) not captured by key/value length alone.
+const entryOverheadBytes = 64
+
+// defaultByteSizer is Config's default Sizer when MaxBytes > 0 but Sizer is unset.
+func defaultByteSizer(key string, value []byte) int64 {
+	return int64(len(key)+len(value)) + entryOverheadBytes
+}
+
+// NewTyped constructs a cache over arbitrary comparable keys and values.
+//
+// Unlike New, NewTyped never clones: Set stores exactly the V it's given, and Get
+// returns exactly the V that was stored, avoiding a defensive copy that only matters
+// for mutable value types. This means callers must not mutate a value after handing
+// it to Set, or mutate one returned by Get — doing so on a V with shared mutable
+// state (a slice, map, or pointer field) would corrupt the cache, or another caller's
+// view of it, without taking the lock.
+//
+// NewTyped never returns a nil Cache[K, V].
+func NewTyped[K comparable, V any](cfg TypedConfig[K, V]) *Cache[K, V] {
+	core := newCacheCore[K, V](cfg.MaxEntries, cfg.CleanupInterval, cfg.Policy, cfg.MaxBytes, cfg.Sizer, cfg.OnEvicted)
+	return wrapCore(core)
+}
+
+func newCacheCore[K comparable, V any](maxEntries int, cleanupEvery time.Duration, policy Policy, maxBytes int64, sizer func(key K, value V) int64, onEvicted func(key K, value V, reason EvictionReason)) *cacheCore[K, V] {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	c := &Cache{
-		maxEntries:   cfg.MaxEntries,
-		items:        make(map[string]*list.Element),
+	c := &cacheCore[K, V]{
+		maxEntries:   maxEntries,
+		policy:       policy,
+		items:        make(map[K]*list.Element),
 		lru:          list.New(),
+		maxBytes:     maxBytes,
+		sizer:        sizer,
+		onEvicted:    onEvicted,
+		timerCh:      make(chan time.Duration, 1),
 		ctx:          ctx,
 		cancel:       cancel,
-		cleanupEvery: cfg.CleanupInterval,
+		loopExited:   make(chan struct{}),
+		cleanupEvery: cleanupEvery,
 	}
+	c.subs.init()
 
 	if c.cleanupEvery > 0 {
 		c.wg.Add(1)
@@ -80,10 +306,29 @@ func New(cfg Config) *Cache {
 	return c
 }
 
+// wrapCore wraps core in the outer Cache handle returned by New/NewTyped, and
+// attaches a finalizer that cancels core's background goroutine if the caller drops
+// every reference to the returned Cache without calling Close. The finalizer is a
+// safety net, not a substitute for Close: it only fires on the GC's schedule (or
+// never, if the process exits first), so it doesn't release resources promptly and
+// doesn't call core.Close itself (which would block this goroutine on wg.Wait).
+func wrapCore[K comparable, V any](core *cacheCore[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{core: core}
+	runtime.SetFinalizer(c, func(c *Cache[K, V]) {
+		c.core.cancel()
+	})
+	return c
+}
+
 // Close stops background goroutines and prevents further mutation.
 //
 // Close is safe to call multiple times.
-func (c *Cache) Close() error {
+func (c *Cache[K, V]) Close() error {
+	runtime.SetFinalizer(c, nil)
+	return c.core.Close()
+}
+
+func (c *cacheCore[K, V]) Close() error {
 	c.mu.Lock()
 	if c.closed {
 		c.mu.Unlock()
@@ -107,14 +352,29 @@ func (c *Cache) Close() error {
 // Complexity:
 //   - O(1) to locate/insert
 //   - O(1) eviction per removed entry
-func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) error {
+	return c.core.Set(key, value, ttl)
+}
+
+func (c *cacheCore[K, V]) Set(key K, value V, ttl time.Duration) error {
+	return c.setSized(key, value, ttl, nil)
+}
+
+// setSized is Set's shared implementation. size overrides sizer's estimate for this
+// entry when non-nil — used by GetOrCompute, whose compute already knows the cost of
+// what it built; a plain Set always passes nil and falls back to c.sizer.
+func (c *cacheCore[K, V]) setSized(key K, value V, ttl time.Duration, size *int64) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
 	if c.closed {
+		c.mu.Unlock()
 		return ErrClosed
 	}
 
+	if c.clone != nil {
+		value = c.clone(value)
+	}
+
 	now := time.Now()
 
 	// Compute expiry once. Using hasExpiry avoids comparing against the zero time.
@@ -124,97 +384,219 @@ func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
 		expiresAt = now.Add(ttl)
 	}
 
+	sz := c.sizeOfLocked(key, value, size)
+
 	if el, ok := c.items[key]; ok {
-		e := el.Value.(*entry)
-		e.value = cloneBytes(value)
+		e := el.Value.(*entry[K, V])
+		e.value = value
+		wasExpiry := e.hasExpiry
 		e.hasExpiry = hasExpiry
 		e.expiresAt = expiresAt
+		c.usedBytes.Add(sz - e.size)
+		e.size = sz
+
+		switch {
+		case wasExpiry && hasExpiry:
+			heap.Fix(&c.expQueue, e.heapIndex)
+		case wasExpiry && !hasExpiry:
+			heap.Remove(&c.expQueue, e.heapIndex)
+		case !wasExpiry && hasExpiry:
+			heap.Push(&c.expQueue, e)
+		}
 
 		// Updating counts as use; move to MRU.
 		c.lru.MoveToFront(el)
-		c.evictIfNeededLocked(now)
-		return nil
-	}
+		c.evictIfNeededLocked(now, nil)
+	} else {
+		e := &entry[K, V]{
+			key:       key,
+			value:     value,
+			hasExpiry: hasExpiry,
+			expiresAt: expiresAt,
+			size:      sz,
+			heapIndex: -1,
+		}
+
+		el := c.lru.PushFront(e)
+		c.items[key] = el
+		c.usedBytes.Add(sz)
+		c.entries.Add(1)
 
-	e := &entry{
-		key:       key,
-		value:     cloneBytes(value),
-		hasExpiry: hasExpiry,
-		expiresAt: expiresAt,
+		if hasExpiry {
+			heap.Push(&c.expQueue, e)
+		}
+
+		// Protect el: it was just inserted by this Set call and hasn't been read
+		// yet, so PolicySIEVE's sweep never chooses it as its own eviction victim
+		// (see sieveVictimLocked). PolicyLRU/PolicyFIFO ignore protect.
+		c.evictIfNeededLocked(now, el)
 	}
 
-	el := c.lru.PushFront(e)
-	c.items[key] = el
+	c.armTimerLocked()
+	pending := c.takePendingLocked()
+	c.mu.Unlock()
+	c.insertions.Add(1)
 
-	c.evictIfNeededLocked(now)
+	// Fire outside the lock so an observer can safely re-enter the cache.
+	c.firePending(pending)
+	c.fireInsertion(key, value)
 	return nil
 }
 
+// sizeOfLocked returns override if set, otherwise c.sizer's estimate (or 0 if sizer
+// is nil).
+func (c *cacheCore[K, V]) sizeOfLocked(key K, value V, override *int64) int64 {
+	if override != nil {
+		return *override
+	}
+	if c.sizer == nil {
+		return 0
+	}
+	return c.sizer(key, value)
+}
+
+// GetOrCompute returns the cached value for key if present, otherwise calls compute
+// to produce it, stores the result with the given ttl and byte size, and returns it.
+// Concurrent GetOrCompute calls for the same key are serialized through a
+// singleflight group: only one of them actually runs compute, and the rest block and
+// share its result, so a thundering herd of misses costs exactly one compute.
+func (c *Cache[K, V]) GetOrCompute(key K, compute func() (V, time.Duration, int64, error)) (V, error) {
+	return c.core.GetOrCompute(key, compute)
+}
+
+func (c *cacheCore[K, V]) GetOrCompute(key K, compute func() (V, time.Duration, int64, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	v, err, _ := c.flight.Do(fmt.Sprint(key), func() (any, error) {
+		// Re-check: another GetOrCompute call may have populated key while we were
+		// waiting to enter Do (e.g. a prior flight for this key already finished).
+		if v, ok := c.Get(key); ok {
+			return v, nil
+		}
+
+		value, ttl, size, err := compute()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.setSized(key, value, ttl, &size); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return v.(V), nil
+}
+
 // Get reads a key.
 //
 // It performs lazy TTL expiration: expired keys are removed on access.
 //
 // Concurrency note:
-// Reads ideally take an RLock, but LRU updates are writes.
+// Reads ideally take an RLock, but recency bookkeeping (PolicyLRU's MoveToFront,
+// PolicySIEVE's visited bit) is a write.
 // We use an "optimistic read then confirm under write lock" pattern:
 //  1. RLock to find entry and check expiry.
 //  2. If present and not expired, release RLock.
-//  3. Lock and re-check, then move node to front and copy value.
+//  3. Lock and re-check, then update recency bookkeeping and copy value.
 //
 // This keeps the uncontended fast-path mostly read-locked, while still being correct.
-func (c *Cache) Get(key string) ([]byte, bool) {
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	return c.core.Get(key)
+}
+
+func (c *cacheCore[K, V]) Get(key K) (V, bool) {
 	now := time.Now()
 
 	c.mu.RLock()
 	el, ok := c.items[key]
 	if !ok {
 		c.mu.RUnlock()
-		return nil, false
+		c.misses.Add(1)
+		var zero V
+		return zero, false
 	}
 
-	e := el.Value.(*entry)
+	e := el.Value.(*entry[K, V])
 	if e.hasExpiry && !e.expiresAt.After(now) {
 		// Expired: must upgrade to write lock to delete.
 		c.mu.RUnlock()
 		c.mu.Lock()
-		defer c.mu.Unlock()
 		c.deleteIfExpiredLocked(key, now)
-		return nil, false
+		pending := c.takePendingLocked()
+		c.mu.Unlock()
+		c.firePending(pending)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
 	}
 
 	// Snapshot what we can under RLock.
-	// We must NOT return e.value directly because callers could mutate it.
-	// Also, we still need to move the LRU node, which requires a write lock.
+	// If clone is set, we must NOT return e.value directly because callers could
+	// mutate it. Also, we still need to move the LRU node, which requires a write lock.
 	c.mu.RUnlock()
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
 	// Re-check because the key could have been deleted/evicted between locks.
 	el2, ok := c.items[key]
 	if !ok {
-		return nil, false
+		c.mu.Unlock()
+		c.misses.Add(1)
+		var zero V
+		return zero, false
 	}
-	e2 := el2.Value.(*entry)
+	e2 := el2.Value.(*entry[K, V])
 	if e2.hasExpiry && !e2.expiresAt.After(now) {
-		c.deleteLocked(key)
-		return nil, false
+		c.deleteLocked(key, EvictionReasonExpired)
+		pending := c.takePendingLocked()
+		c.mu.Unlock()
+		c.firePending(pending)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
 	}
 
-	c.lru.MoveToFront(el2)
-	return cloneBytes(e2.value), true
+	switch c.policy {
+	case PolicyLRU:
+		c.lru.MoveToFront(el2)
+	case PolicySIEVE:
+		e2.visited = true
+	case PolicyFIFO:
+		// No reordering and no bookkeeping: eviction order is pure insertion order.
+	}
+
+	v := e2.value
+	if c.clone != nil {
+		v = c.clone(v)
+	}
+	c.mu.Unlock()
+	c.hits.Add(1)
+	return v, true
 }
 
 // Delete removes a key if present.
-func (c *Cache) Delete(key string) error {
+func (c *Cache[K, V]) Delete(key K) error {
+	return c.core.Delete(key)
+}
+
+func (c *cacheCore[K, V]) Delete(key K) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
 	if c.closed {
+		c.mu.Unlock()
 		return ErrClosed
 	}
 
-	c.deleteLocked(key)
+	c.deleteLocked(key, EvictionReasonDeleted)
+	pending := c.takePendingLocked()
+	c.mu.Unlock()
+
+	c.firePending(pending)
 	return nil
 }
 
@@ -222,7 +604,11 @@ func (c *Cache) Delete(key string) error {
 //
 // Note: Len includes entries that have expired but haven't been cleaned up yet.
 // Lazy expiration removes them when accessed; the cleanup loop removes them over time.
-func (c *Cache) Len() int {
+func (c *Cache[K, V]) Len() int {
+	return c.core.Len()
+}
+
+func (c *cacheCore[K, V]) Len() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return len(c.items)
@@ -231,75 +617,268 @@ func (c *Cache) Len() int {
 // Keys returns keys in MRU -> LRU order.
 //
 // This is a debug/teaching helper used by the demo.
-func (c *Cache) Keys() []string {
+func (c *Cache[K, V]) Keys() []K {
+	return c.core.Keys()
+}
+
+func (c *cacheCore[K, V]) Keys() []K {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	out := make([]string, 0, c.lru.Len())
+	out := make([]K, 0, c.lru.Len())
 	for el := c.lru.Front(); el != nil; el = el.Next() {
-		out = append(out, el.Value.(*entry).key)
+		out = append(out, el.Value.(*entry[K, V]).key)
 	}
 	return out
 }
 
-func (c *Cache) evictIfNeededLocked(now time.Time) {
-	if c.maxEntries <= 0 {
-		return
+// Stats is a point-in-time snapshot of a Cache's activity counters, returned by
+// Cache.Stats.
+type Stats struct {
+	Hits       int64
+	Misses     int64
+	Insertions int64
+
+	// Evictions, broken down by why the entry left.
+	EvictionsDeleted         int64
+	EvictionsCapacityReached int64
+	EvictionsExpired         int64
+
+	// Entries and Bytes are current gauges, not cumulative counters: they reflect
+	// what's in the cache right now, not how many entries/bytes have ever passed
+	// through it.
+	Entries int64
+	Bytes   int64
+}
+
+// Stats returns a snapshot of the cache's activity counters. Unlike most Cache
+// methods, Stats doesn't take the cache mutex: every counter is an atomic.Int64
+// (Entries/Bytes are updated while the mutation holds mu; Hits, Misses and
+// Insertions just after it releases mu), so Stats can be called from a metrics scrape loop without contending with Set/Get/Delete.
+func (c *Cache[K, V]) Stats() Stats {
+	return c.core.Stats()
+}
+
+func (c *cacheCore[K, V]) Stats() Stats {
+	return Stats{
+		Hits:                     c.hits.Load(),
+		Misses:                   c.misses.Load(),
+		Insertions:               c.insertions.Load(),
+		EvictionsDeleted:         c.evictionsDeleted.Load(),
+		EvictionsCapacityReached: c.evictionsCapacityReached.Load(),
+		EvictionsExpired:         c.evictionsExpired.Load(),
+		Entries:                  c.entries.Load(),
+		Bytes:                    c.usedBytes.Load(),
 	}
+}
 
+// protect, if non-nil, is the element this Set call just inserted, which hasn't
+// been read yet. PolicySIEVE never picks it as an eviction victim; PolicyLRU and
+// PolicyFIFO take the list's back regardless, which is protect only when it is the
+// sole entry left (an entry over MaxBytes on its own is then evicted by its own Set).
+func (c *cacheCore[K, V]) evictIfNeededLocked(now time.Time, protect *list.Element) {
 	// Prefer to reclaim expired entries first if we're under pressure.
 	// This keeps LRU semantics for live keys while treating expired keys as already dead.
-	c.deleteExpiredLocked(now)
+	c.popExpiredLocked(now)
 
-	for len(c.items) > c.maxEntries {
-		el := c.lru.Back()
+	for c.overBudgetLocked() {
+		el := c.evictionVictimLocked(protect)
 		if el == nil {
 			return
 		}
-		e := el.Value.(*entry)
-		c.deleteLocked(e.key)
+		e := el.Value.(*entry[K, V])
+		c.deleteLocked(e.key, EvictionReasonCapacityReached)
+	}
+}
+
+// overBudgetLocked reports whether the cache exceeds MaxEntries or MaxBytes.
+// Either budget <= 0 means "unbounded" for that dimension.
+func (c *cacheCore[K, V]) overBudgetLocked() bool {
+	if c.maxEntries > 0 && len(c.items) > c.maxEntries {
+		return true
+	}
+	if c.maxBytes > 0 && c.usedBytes.Load() > c.maxBytes {
+		return true
 	}
+	return false
 }
 
-func (c *Cache) deleteLocked(key string) {
+// evictionVictimLocked picks the next capacity-eviction victim per c.policy.
+// PolicyLRU and PolicyFIFO share the same list node (the back): PolicyFIFO simply
+// never reorders the list on Get, so the back is always the oldest surviving key.
+func (c *cacheCore[K, V]) evictionVictimLocked(protect *list.Element) *list.Element {
+	if c.policy == PolicySIEVE {
+		return c.sieveVictimLocked(protect)
+	}
+	return c.lru.Back()
+}
+
+// sieveVictimLocked implements the SIEVE eviction sweep: starting from the
+// persistent hand (or the tail, on the first call), walk toward the head clearing
+// visited bits until an unvisited entry is found; that entry is the victim, and the
+// hand is left just past it (toward the head) for the next sweep. The walk wraps
+// from the head back to the tail, so it always terminates: a full lap clears every
+// remaining bit, guaranteeing the next entry revisited is unvisited.
+//
+// protect is skipped by the sweep without being evicted or having its visited bit
+// touched: it's the entry this same Set call just inserted, which canonical SIEVE
+// never considers for eviction until a later pass reads or re-inserts it. If protect
+// is the only entry left in the list, skipping it forever would spin without making
+// progress, so the walk is bounded to one lap past the list length and returns nil
+// (no legal victim) instead of looping — the caller then stops trying to evict.
+func (c *cacheCore[K, V]) sieveVictimLocked(protect *list.Element) *list.Element {
+	hand := c.sieveHand
+	if hand == nil {
+		hand = c.lru.Back()
+	}
+
+	for steps := c.lru.Len() + 1; hand != nil && steps > 0; steps-- {
+		if hand == protect {
+			hand = wrapToward(hand.Prev(), c.lru)
+			continue
+		}
+
+		e := hand.Value.(*entry[K, V])
+		if e.visited {
+			e.visited = false
+			hand = wrapToward(hand.Prev(), c.lru)
+			continue
+		}
+
+		c.sieveHand = wrapToward(hand.Prev(), c.lru)
+		return hand
+	}
+	return nil
+}
+
+// wrapToward returns prev, or list.Back() if prev is nil (the sweep walked off the
+// head and should continue from the tail).
+func wrapToward(prev *list.Element, l *list.List) *list.Element {
+	if prev == nil {
+		return l.Back()
+	}
+	return prev
+}
+
+// deleteLocked unlinks key from items/lru/expQueue (if present) and stages an
+// eviction notification for the given reason. Callers must drain and fire c.pending
+// (via takePendingLocked/firePending) after releasing mu.
+func (c *cacheCore[K, V]) deleteLocked(key K, reason EvictionReason) {
 	el, ok := c.items[key]
 	if !ok {
 		return
 	}
+	e := el.Value.(*entry[K, V])
+
+	if c.policy == PolicySIEVE && c.sieveHand == el {
+		c.sieveHand = wrapToward(el.Prev(), c.lru)
+		if c.sieveHand == el {
+			c.sieveHand = nil // el was the only entry in the list
+		}
+	}
+
 	delete(c.items, key)
 	c.lru.Remove(el)
+	c.usedBytes.Add(-e.size)
+	c.entries.Add(-1)
+	if e.heapIndex >= 0 {
+		heap.Remove(&c.expQueue, e.heapIndex)
+	}
+	c.countEviction(reason)
+	c.pending = append(c.pending, pendingEviction[K, V]{key: key, value: e.value, reason: reason})
 }
 
-func (c *Cache) deleteIfExpiredLocked(key string, now time.Time) bool {
+// countEviction bumps the Stats counter matching reason.
+func (c *cacheCore[K, V]) countEviction(reason EvictionReason) {
+	switch reason {
+	case EvictionReasonDeleted:
+		c.evictionsDeleted.Add(1)
+	case EvictionReasonCapacityReached:
+		c.evictionsCapacityReached.Add(1)
+	case EvictionReasonExpired:
+		c.evictionsExpired.Add(1)
+	}
+}
+
+func (c *cacheCore[K, V]) deleteIfExpiredLocked(key K, now time.Time) bool {
 	el, ok := c.items[key]
 	if !ok {
 		return false
 	}
-	e := el.Value.(*entry)
+	e := el.Value.(*entry[K, V])
 	if e.hasExpiry && !e.expiresAt.After(now) {
-		c.deleteLocked(key)
+		c.deleteLocked(key, EvictionReasonExpired)
 		return true
 	}
 	return false
 }
 
-// deleteExpiredLocked removes all expired keys.
-//
-// This is O(n) and intentionally simple. More complex designs can track expirations
-// in a min-heap or timing wheel, but that trades simplicity for performance.
-func (c *Cache) deleteExpiredLocked(now time.Time) int {
+// popExpiredLocked reaps every entry whose expiresAt <= now by repeatedly popping
+// expQueue's head, which is always the earliest-expiring entry still in the cache.
+// This is O(log n) per reaped entry instead of the O(n) full-map scan it replaces.
+func (c *cacheCore[K, V]) popExpiredLocked(now time.Time) int {
 	removed := 0
-	for key, el := range c.items {
-		e := el.Value.(*entry)
-		if e.hasExpiry && !e.expiresAt.After(now) {
-			delete(c.items, key)
-			c.lru.Remove(el)
-			removed++
+	for len(c.expQueue) > 0 {
+		e := c.expQueue[0]
+		if e.expiresAt.After(now) {
+			break
 		}
+		heap.Pop(&c.expQueue)
+		// Route through deleteLocked rather than unlinking items/lru directly, so
+		// this path gets the same sieveHand fixup explicit/capacity deletes do —
+		// otherwise a reaped entry that happened to be the SIEVE hand would leave
+		// it pointing at an unlinked list.Element.
+		c.deleteLocked(e.key, EvictionReasonExpired)
+		removed++
 	}
 	return removed
 }
 
+// armTimerLocked signals expiryLoop with the current head's remaining TTL, if there
+// is one. It's only called from Set: deletions can only remove the head early (never
+// move it sooner), so a stale timer from a deleted entry wakes the loop a bit early
+// at worst — popExpiredLocked finds nothing due yet and the loop re-arms correctly.
+func (c *cacheCore[K, V]) armTimerLocked() {
+	if len(c.expQueue) == 0 {
+		return
+	}
+	c.signalTimer(time.Until(c.expQueue[0].expiresAt))
+}
+
+// signalTimer delivers d to timerCh, draining one stale pending value first if the
+// buffered channel is already full, so this never blocks the caller (which holds mu).
+func (c *cacheCore[K, V]) signalTimer(d time.Duration) {
+	for {
+		select {
+		case c.timerCh <- d:
+			return
+		default:
+		}
+		select {
+		case <-c.timerCh:
+		default:
+		}
+	}
+}
+
+// takePendingLocked drains the queued eviction notifications. Must be called while
+// holding mu; the returned events must only be fired (via firePending) after mu has
+// been released, so observers can safely re-enter the cache.
+func (c *cacheCore[K, V]) takePendingLocked() []pendingEviction[K, V] {
+	if len(c.pending) == 0 {
+		return nil
+	}
+	pending := c.pending
+	c.pending = nil
+	return pending
+}
+
+func (c *cacheCore[K, V]) firePending(pending []pendingEviction[K, V]) {
+	for _, p := range pending {
+		c.fireEviction(p.key, p.value, p.reason)
+	}
+}
+
 func cloneBytes(b []byte) []byte {
 	if b == nil {
 		return nil
@@ -1,6 +1,10 @@
 package cache
 
 import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -37,6 +41,139 @@ func TestLRUEviction(t *testing.T) {
 	}
 }
 
+func TestFIFOEviction(t *testing.T) {
+	c := New(Config{MaxEntries: 2, Policy: PolicyFIFO})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("A"), 0)
+	_ = c.Set("b", []byte("B"), 0)
+
+	// Touching a would make b the LRU under PolicyLRU, but PolicyFIFO must ignore
+	// access recency and evict strictly in insertion order regardless.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to exist")
+	}
+
+	_ = c.Set("c", []byte("C"), 0) // should evict a, the oldest insertion
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be evicted despite being recently accessed")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("expected b to remain")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("expected c to exist")
+	}
+}
+
+func TestSIEVEEviction(t *testing.T) {
+	c := New(Config{MaxEntries: 2, Policy: PolicySIEVE})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("A"), 0)
+	_ = c.Set("b", []byte("B"), 0)
+
+	// Mark a visited; the hand starts at the tail (a, the oldest insert), clears its
+	// visited bit and advances to b, which is unvisited, evicting b rather than a.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to exist")
+	}
+
+	_ = c.Set("c", []byte("C"), 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected b to be evicted, a was visited")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to remain")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("expected c to exist")
+	}
+}
+
+func TestSIEVE_ExpiryInvalidatesStaleHand(t *testing.T) {
+	c := New(Config{MaxEntries: 10, Policy: PolicySIEVE})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("A"), 20*time.Millisecond)
+	_ = c.Set("b", []byte("B"), 0)
+	_ = c.Set("c", []byte("C"), 0)
+
+	// Pin the hand at "a", the entry about to expire, the same way a capacity
+	// eviction would have left it there.
+	core := c.core
+	core.mu.Lock()
+	staleEl := core.items["a"]
+	core.sieveHand = staleEl
+	core.mu.Unlock()
+
+	time.Sleep(40 * time.Millisecond)
+
+	core.mu.Lock()
+	core.popExpiredLocked(time.Now())
+	handAfterExpiry := core.sieveHand
+	victim := core.sieveVictimLocked(nil)
+	core.mu.Unlock()
+
+	if handAfterExpiry == staleEl {
+		t.Fatalf("expected popExpiredLocked to move the SIEVE hand off the reaped element")
+	}
+	if victim == staleEl {
+		t.Fatalf("expected sieveVictimLocked to never return the already-reaped element")
+	}
+	if victim == nil {
+		t.Fatalf("expected a live victim among the remaining entries")
+	}
+	if _, ok := core.items[victim.Value.(*entry[string, []byte]).key]; !ok {
+		t.Fatalf("expected the returned victim to still be a live member of items")
+	}
+}
+
+func TestSIEVE_HandSweepClearsVisitedAndWraps(t *testing.T) {
+	c := New(Config{MaxEntries: 3, Policy: PolicySIEVE})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("A"), 0)
+	_ = c.Set("b", []byte("B"), 0)
+	_ = c.Set("c", []byte("C"), 0)
+
+	// Mark every entry visited: the hand must sweep clearing bits, wrap from the
+	// head back to the tail, and evict whichever it revisits first unvisited.
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+
+	_ = c.Set("d", []byte("D"), 0) // forces one eviction among a/b/c
+
+	survivors := 0
+	for _, k := range []string{"a", "b", "c"} {
+		if _, ok := c.Get(k); ok {
+			survivors++
+		}
+	}
+	if survivors != 2 {
+		t.Fatalf("expected exactly one of a/b/c evicted, got %d survivors", survivors)
+	}
+	if _, ok := c.Get("d"); !ok {
+		t.Fatalf("expected d to exist")
+	}
+
+	// A second eviction must also terminate promptly rather than looping forever,
+	// proving the hand actually cleared (and can re-clear) visited bits as it wraps.
+	_ = c.Set("e", []byte("E"), 0)
+	total := 0
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		if _, ok := c.Get(k); ok {
+			total++
+		}
+	}
+	if total != 3 {
+		t.Fatalf("expected exactly 3 surviving keys after two evictions, got %d", total)
+	}
+}
+
 func TestTTL_LazyExpirationOnGet(t *testing.T) {
 	c := New(Config{MaxEntries: 10, CleanupInterval: 0})
 	defer c.Close()
@@ -88,6 +225,34 @@ func TestTTL_BackgroundCleanupRemovesWithoutGet(t *testing.T) {
 	}
 }
 
+func TestTTL_BackgroundExpiryIsHeapDrivenNotPolled(t *testing.T) {
+	// CleanupInterval only opts the goroutine in now; it no longer sets a polling
+	// period. A huge CleanupInterval must still reap a short-lived key promptly,
+	// proving expiry is driven by the expiration heap's head, not a fixed ticker.
+	c := New(Config{MaxEntries: 10, CleanupInterval: time.Hour})
+	defer c.Close()
+
+	if err := c.Set("ttl", []byte("v"), 20*time.Millisecond); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		found := false
+		for _, k := range c.Keys() {
+			if k == "ttl" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return // success
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected ttl to be reaped well before the 1h CleanupInterval elapsed")
+}
+
 func TestClose_IdempotentAndPreventsMutation(t *testing.T) {
 	c := New(Config{MaxEntries: 1, CleanupInterval: 10 * time.Millisecond})
 
@@ -105,3 +270,426 @@ func TestClose_IdempotentAndPreventsMutation(t *testing.T) {
 		t.Fatalf("expected Delete to fail after close")
 	}
 }
+
+func TestTyped_LRUAndTTL(t *testing.T) {
+	c := NewTyped[string, int](TypedConfig[string, int]{MaxEntries: 2, CleanupInterval: 0})
+	defer c.Close()
+
+	if err := c.Set("a", 1, 0); err != nil {
+		t.Fatalf("set a: %v", err)
+	}
+	if err := c.Set("b", 2, 0); err != nil {
+		t.Fatalf("set b: %v", err)
+	}
+
+	// Touch a so b becomes LRU.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to exist")
+	}
+
+	// Insert c => should evict b.
+	if err := c.Set("c", 3, 0); err != nil {
+		t.Fatalf("set c: %v", err)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+
+	if err := c.Set("d", 4, 20*time.Millisecond); err != nil {
+		t.Fatalf("set d: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := c.Get("d"); ok {
+		t.Fatalf("expected d to be expired and removed on get")
+	}
+}
+
+func TestTyped_NoCloning(t *testing.T) {
+	c := NewTyped[string, []byte](TypedConfig[string, []byte]{MaxEntries: 10, CleanupInterval: 0})
+	defer c.Close()
+
+	v := []byte("hello")
+	if err := c.Set("k", v, 0); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	// NewTyped does not clone: mutating the slice we handed to Set must be visible
+	// on the next Get, unlike the byte-slice-specialized Cache returned by New.
+	v[0] = 'H'
+
+	got, ok := c.Get("k")
+	if !ok {
+		t.Fatalf("expected k to exist")
+	}
+	if string(got) != "Hello" {
+		t.Fatalf("expected NewTyped to store by reference, got %q", got)
+	}
+}
+
+func TestOnEvicted_ReasonsAndOutsideLock(t *testing.T) {
+	var mu sync.Mutex
+	reasons := make(map[string]EvictionReason)
+
+	var c *Cache[string, []byte]
+	c = New(Config{
+		MaxEntries: 1,
+		OnEvicted: func(key string, value []byte, reason EvictionReason) {
+			// Re-entering the cache here proves OnEvicted runs outside c.mu: if it
+			// ran with the lock held, this Get would deadlock.
+			c.Get("sentinel")
+
+			mu.Lock()
+			reasons[key] = reason
+			mu.Unlock()
+		},
+	})
+	defer c.Close()
+
+	_ = c.Set("sentinel", []byte("s"), 0)
+
+	_ = c.Set("a", []byte("A"), 30*time.Millisecond)
+	_ = c.Set("b", []byte("B"), 0) // evicts "a" on capacity, reason CapacityReached
+	_ = c.Delete("b")              // reason Deleted
+
+	_ = c.Set("c", []byte("C"), 30*time.Millisecond)
+	time.Sleep(80 * time.Millisecond)
+	c.Get("c") // lazily expires "c", reason Expired
+
+	mu.Lock()
+	defer mu.Unlock()
+	if reasons["a"] != EvictionReasonCapacityReached {
+		t.Fatalf("expected a evicted for capacity, got %v", reasons["a"])
+	}
+	if reasons["b"] != EvictionReasonDeleted {
+		t.Fatalf("expected b evicted for delete, got %v", reasons["b"])
+	}
+	if reasons["c"] != EvictionReasonExpired {
+		t.Fatalf("expected c evicted for expiry, got %v", reasons["c"])
+	}
+}
+
+func TestEventSubscriptions_MultipleObserversAndUnsubscribe(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	var mu sync.Mutex
+	var insertsA, insertsB, evictions int
+
+	unsubA := c.OnInsertion(func(key string, value []byte) {
+		mu.Lock()
+		insertsA++
+		mu.Unlock()
+	})
+	c.OnInsertion(func(key string, value []byte) {
+		mu.Lock()
+		insertsB++
+		mu.Unlock()
+	})
+	c.OnEviction(func(key string, value []byte, reason EvictionReason) {
+		mu.Lock()
+		evictions++
+		mu.Unlock()
+	})
+
+	_ = c.Set("k", []byte("v"), 0)
+	_ = c.Delete("k")
+
+	unsubA()
+	_ = c.Set("k2", []byte("v2"), 0)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if insertsA != 1 {
+		t.Fatalf("expected unsubscribed observer to stop receiving events, got %d inserts", insertsA)
+	}
+	if insertsB != 2 {
+		t.Fatalf("expected still-subscribed observer to see both inserts, got %d", insertsB)
+	}
+	if evictions != 1 {
+		t.Fatalf("expected 1 eviction, got %d", evictions)
+	}
+}
+
+func TestEventSubscriptions_SelfUnsubscribeDoesNotDeadlock(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	var evictions int
+	var unsub func()
+	unsub = c.OnEviction(func(key string, value []byte, reason EvictionReason) {
+		evictions++
+		unsub()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		_ = c.Set("k", []byte("v"), 0)
+		_ = c.Delete("k")
+		_ = c.Set("k2", []byte("v2"), 0)
+		_ = c.Delete("k2")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Delete did not return: unsubscribing from within the callback likely deadlocked")
+	}
+
+	if evictions != 1 {
+		t.Fatalf("expected the callback to fire exactly once before unsubscribing itself, got %d", evictions)
+	}
+}
+
+func TestMaxBytes_EvictsOnByteBudgetEvenUnderMaxEntries(t *testing.T) {
+	// MaxEntries is generous; only the byte budget should force eviction here.
+	c := New(Config{
+		MaxEntries: 100,
+		MaxBytes:   int64(entryOverheadBytes + len("a") + len("AAAAAAAAAA")),
+	})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("AAAAAAAAAA"), 0) // fills the byte budget exactly
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to exist")
+	}
+
+	_ = c.Set("b", []byte("B"), 0) // any additional bytes must evict a to stay in budget
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be evicted once the byte budget was exceeded")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("expected b to exist")
+	}
+}
+
+func TestSIEVE_OversizedEntryUnderMaxBytesDoesNotHang(t *testing.T) {
+	// A single entry that alone exceeds MaxBytes can't be evicted without evicting
+	// itself: sieveVictimLocked must give up and return nil rather than spin
+	// forever skipping the protected, just-inserted entry.
+	c := New(Config{
+		Policy:   PolicySIEVE,
+		MaxBytes: 1,
+		Sizer: func(key string, value []byte) int64 {
+			return 100
+		},
+	})
+	defer c.Close()
+
+	done := make(chan struct{})
+	go func() {
+		_ = c.Set("a", []byte("A"), 0)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Set did not return: sieveVictimLocked is likely spinning on the protected entry")
+	}
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected the oversized entry to still be stored, over budget or not")
+	}
+}
+
+func TestMaxBytes_CustomSizer(t *testing.T) {
+	c := New(Config{
+		MaxEntries: 100,
+		MaxBytes:   2,
+		Sizer: func(key string, value []byte) int64 {
+			return 1 // every entry costs exactly 1, regardless of actual length
+		},
+	})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("tiny"), 0)
+	_ = c.Set("b", []byte("also tiny"), 0)
+	_ = c.Set("c", []byte("evicts a"), 0) // budget of 2 means only 2 entries fit
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("expected a to be evicted under the custom sizer's byte budget")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("expected b to remain")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("expected c to exist")
+	}
+}
+
+func TestGetOrCompute_CachesResultAndSkipsComputeOnHit(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	var calls int32
+	compute := func() ([]byte, time.Duration, int64, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("computed"), 0, 8, nil
+	}
+
+	v, err := c.GetOrCompute("k", compute)
+	if err != nil {
+		t.Fatalf("getorcompute: %v", err)
+	}
+	if string(v) != "computed" {
+		t.Fatalf("expected computed value, got %q", v)
+	}
+
+	v, err = c.GetOrCompute("k", compute)
+	if err != nil {
+		t.Fatalf("getorcompute (cached): %v", err)
+	}
+	if string(v) != "computed" {
+		t.Fatalf("expected cached value, got %q", v)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected compute to run exactly once, ran %d times", calls)
+	}
+}
+
+func TestGetOrCompute_ConcurrentMissesShareOneCompute(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	var calls int32
+	start := make(chan struct{})
+	compute := func() ([]byte, time.Duration, int64, error) {
+		atomic.AddInt32(&calls, 1)
+		<-start // hold the flight's compute open until every caller has been launched
+		return []byte("v"), 0, 1, nil
+	}
+
+	const n = 10
+	results := make(chan []byte, n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrCompute("shared", compute)
+			if err != nil {
+				t.Errorf("getorcompute: %v", err)
+				return
+			}
+			results <- v
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(results)
+
+	for v := range results {
+		if string(v) != "v" {
+			t.Fatalf("expected every caller to get the shared result, got %q", v)
+		}
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected exactly 1 compute call for a thundering herd, got %d", calls)
+	}
+}
+
+func TestGetOrCompute_ErrorNotCached(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	errBoom := errors.New("boom")
+	_, err := c.GetOrCompute("k", func() ([]byte, time.Duration, int64, error) {
+		return nil, 0, 0, errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected compute's error to propagate, got %v", err)
+	}
+
+	if _, ok := c.Get("k"); ok {
+		t.Fatalf("expected a failed compute to leave nothing cached")
+	}
+}
+
+func TestFinalizer_StopsExpiryLoopWithoutExplicitClose(t *testing.T) {
+	sentinel := newUnreachableCacheLoopExited()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		runtime.Gosched()
+		select {
+		case <-sentinel:
+			return // success: the finalizer cancelled the core and expiryLoop exited
+		default:
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected expiryLoop to exit via the finalizer after the Cache became unreachable")
+}
+
+// newUnreachableCacheLoopExited builds a Cache with its background goroutine
+// running, returns the inner core's loopExited sentinel, and lets the Cache itself
+// go out of scope without calling Close — isolated in its own function so no local
+// variable in the caller's frame keeps the Cache reachable.
+func newUnreachableCacheLoopExited() <-chan struct{} {
+	c := New(Config{MaxEntries: 10, CleanupInterval: time.Millisecond})
+	return c.core.loopExited
+}
+
+func TestStats_CountsHitsMissesInsertionsAndEvictionsByReason(t *testing.T) {
+	c := New(Config{MaxEntries: 1})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("A"), 0) // insertion
+	c.Get("a")                     // hit
+	c.Get("missing")               // miss
+
+	_ = c.Set("b", []byte("B"), 0) // insertion, evicts a (capacity)
+	_ = c.Delete("b")              // evicts b (deleted)
+
+	_ = c.Set("c", []byte("C"), 30*time.Millisecond) // insertion
+	time.Sleep(80 * time.Millisecond)
+	c.Get("c") // miss, evicts c (expired)
+
+	stats := c.Stats()
+	if stats.Hits != 1 {
+		t.Fatalf("expected 1 hit, got %d", stats.Hits)
+	}
+	if stats.Misses != 2 {
+		t.Fatalf("expected 2 misses, got %d", stats.Misses)
+	}
+	if stats.Insertions != 3 {
+		t.Fatalf("expected 3 insertions, got %d", stats.Insertions)
+	}
+	if stats.EvictionsCapacityReached != 1 {
+		t.Fatalf("expected 1 capacity eviction, got %d", stats.EvictionsCapacityReached)
+	}
+	if stats.EvictionsDeleted != 1 {
+		t.Fatalf("expected 1 delete eviction, got %d", stats.EvictionsDeleted)
+	}
+	if stats.EvictionsExpired != 1 {
+		t.Fatalf("expected 1 expiry eviction, got %d", stats.EvictionsExpired)
+	}
+	if stats.Entries != 0 {
+		t.Fatalf("expected 0 entries remaining, got %d", stats.Entries)
+	}
+}
+
+func TestStats_EntriesAndBytesTrackLiveState(t *testing.T) {
+	c := New(Config{MaxEntries: 10})
+	defer c.Close()
+
+	_ = c.Set("a", []byte("AAAA"), 0)
+	_ = c.Set("b", []byte("BB"), 0)
+
+	stats := c.Stats()
+	if stats.Entries != 2 {
+		t.Fatalf("expected 2 entries, got %d", stats.Entries)
+	}
+	if stats.Bytes != 0 {
+		t.Fatalf("expected 0 bytes tracked without MaxBytes/Sizer configured, got %d", stats.Bytes)
+	}
+
+	_ = c.Delete("a")
+	if got := c.Stats().Entries; got != 1 {
+		t.Fatalf("expected 1 entry after delete, got %d", got)
+	}
+}
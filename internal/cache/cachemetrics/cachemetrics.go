@@ -0,0 +1,79 @@
+// Package cachemetrics exports a cache.Cache's Stats as a Prometheus collector, so a
+// service embedding GoCache can register it with a prometheus.Registry and scrape it
+// without writing any glue code of its own.
+package cachemetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gocache/internal/cache"
+)
+
+// Collector implements prometheus.Collector over a *cache.Cache[K, V]'s Stats.
+// Collect calls Stats on every scrape: it never touches the cache's own mutex (Stats
+// reads atomic counters), so scraping never contends with Set/Get/Delete.
+type Collector[K comparable, V any] struct {
+	cache *cache.Cache[K, V]
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	insertions *prometheus.Desc
+	evictions  *prometheus.Desc
+	entries    *prometheus.Desc
+	bytes      *prometheus.Desc
+}
+
+// NewCollector wraps c, naming every exported metric "<namespace>_cache_<...>".
+func NewCollector[K comparable, V any](c *cache.Cache[K, V], namespace string) *Collector[K, V] {
+	return &Collector[K, V]{
+		cache: c,
+		hits: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "hits_total"),
+			"Total number of Get calls that found a live entry.", nil, nil,
+		),
+		misses: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "misses_total"),
+			"Total number of Get calls that found no entry, or found one that had expired.", nil, nil,
+		),
+		insertions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "insertions_total"),
+			"Total number of successful Set calls.", nil, nil,
+		),
+		evictions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "evictions_total"),
+			"Total number of entries that left the cache, by reason.", []string{"reason"}, nil,
+		),
+		entries: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "entries"),
+			"Current number of entries stored in the cache.", nil, nil,
+		),
+		bytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cache", "bytes"),
+			"Current estimated size of the cache in bytes, per Config.Sizer.", nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector[K, V]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.insertions
+	ch <- c.evictions
+	ch <- c.entries
+	ch <- c.bytes
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector[K, V]) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.insertions, prometheus.CounterValue, float64(stats.Insertions))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.EvictionsDeleted), "deleted")
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.EvictionsCapacityReached), "capacity")
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.EvictionsExpired), "expired")
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Entries))
+	ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.GaugeValue, float64(stats.Bytes))
+}
@@ -1,9 +1,40 @@
 // Package cache implements a single-process, in-memory key–value cache.
 //
 // Goals for this package:
-//   - Make the core data structures explicit (map + doubly-linked list)
+//   - Make the core data structures explicit (map + doubly-linked list + min-heap)
 //   - Provide O(1) Set/Get/Delete via map index + LRU pointers
 //   - Be concurrency-safe (RWMutex) with correctness as the primary goal
-//   - Support per-entry TTL with both lazy and active expiration
+//   - Support per-entry TTL with both lazy and active expiration, the latter driven
+//     by a min-heap of expiring entries rather than a periodic full-map scan
 //   - Own and cleanly stop long-lived goroutines (no leaks on shutdown)
+//
+// Cache[K, V] is generic over key and value types. New returns the original
+// string/[]byte specialization with defensive cloning; NewTyped returns a cache over
+// any comparable K and any V, without cloning, for callers storing typed values who
+// don't want []byte serialization or interface{} boxing.
+//
+// Cache is a thin handle over an inner cacheCore that owns the background expiry
+// goroutine; New/NewTyped attach a finalizer to the handle so a caller who drops it
+// without calling Close doesn't leak that goroutine. Close remains the reliable,
+// prompt way to release resources — the finalizer is a last-resort safety net.
+//
+// Callers can observe cache activity without polling: Config/TypedConfig.OnEvicted is
+// a single eviction hook (with a reason: deleted, capacity, or expired), and
+// Cache.OnInsertion/OnEviction support any number of independent subscribers.
+// Callbacks always run outside the cache's mutex, so they may safely call back into
+// the cache.
+//
+// Config/TypedConfig.Policy selects how a capacity-triggered eviction picks its
+// victim: PolicyLRU (the default), PolicyFIFO, or PolicySIEVE. Policy has no effect
+// on TTL expiration, which always reaps whatever is due.
+//
+// MaxBytes/Sizer add an optional memory budget alongside MaxEntries: eviction keeps
+// running until both are satisfied. GetOrCompute builds on this for cache-aside
+// workloads, serializing concurrent misses for the same key so a thundering herd
+// only computes the value once.
+//
+// Cache.Stats reports hit/miss/insertion/eviction counters and current entry/byte
+// gauges via atomic.Int64, so it never contends with the cache's own mutex. The
+// cachemetrics subpackage exposes the same data as a prometheus.Collector for
+// callers who want to scrape it directly.
 package cache
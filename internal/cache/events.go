@@ -0,0 +1,101 @@
+package cache
+
+import "sync"
+
+// subscriptions holds the Cache.OnInsertion/OnEviction subscriber sets. It's a
+// separate mutex from cacheCore.mu: firing a subscriber never happens while mu is held
+// (see firePending/fireInsertion call sites), but subscribe/unsubscribe need their
+// own lock since they can race each other independently of cache mutations.
+type subscriptions[K comparable, V any] struct {
+	mu       sync.RWMutex
+	nextID   int
+	inserted map[int]func(key K, value V)
+	evicted  map[int]func(key K, value V, reason EvictionReason)
+}
+
+func (s *subscriptions[K, V]) init() {
+	s.inserted = make(map[int]func(key K, value V))
+	s.evicted = make(map[int]func(key K, value V, reason EvictionReason))
+}
+
+// OnInsertion subscribes fn to every successful Set (including overwrites of an
+// existing key). fn is invoked outside the cache's mutex. The returned unsubscribe
+// func removes fn; it's safe to call at most once and safe to call from within fn
+// itself.
+func (c *Cache[K, V]) OnInsertion(fn func(key K, value V)) (unsubscribe func()) {
+	return c.core.OnInsertion(fn)
+}
+
+func (c *cacheCore[K, V]) OnInsertion(fn func(key K, value V)) (unsubscribe func()) {
+	c.subs.mu.Lock()
+	defer c.subs.mu.Unlock()
+
+	id := c.subs.nextID
+	c.subs.nextID++
+	c.subs.inserted[id] = fn
+
+	return func() {
+		c.subs.mu.Lock()
+		defer c.subs.mu.Unlock()
+		delete(c.subs.inserted, id)
+	}
+}
+
+// OnEviction subscribes fn to every eviction (Delete, LRU/capacity eviction, or TTL
+// expiration), alongside the single Config.OnEvicted hook if one is set. fn is
+// invoked outside the cache's mutex. The returned unsubscribe func removes fn; it's
+// safe to call at most once and safe to call from within fn itself.
+func (c *Cache[K, V]) OnEviction(fn func(key K, value V, reason EvictionReason)) (unsubscribe func()) {
+	return c.core.OnEviction(fn)
+}
+
+func (c *cacheCore[K, V]) OnEviction(fn func(key K, value V, reason EvictionReason)) (unsubscribe func()) {
+	c.subs.mu.Lock()
+	defer c.subs.mu.Unlock()
+
+	id := c.subs.nextID
+	c.subs.nextID++
+	c.subs.evicted[id] = fn
+
+	return func() {
+		c.subs.mu.Lock()
+		defer c.subs.mu.Unlock()
+		delete(c.subs.evicted, id)
+	}
+}
+
+func (c *cacheCore[K, V]) fireInsertion(key K, value V) {
+	// Snapshot the subscriber funcs under the lock, then invoke them after
+	// releasing it — matching firePending's pattern in cache.go. Holding subs.mu
+	// across the calls would deadlock a subscriber that unsubscribes itself, since
+	// its unsubscribe func needs the same mutex as a writer.
+	c.subs.mu.RLock()
+	fns := make([]func(key K, value V), 0, len(c.subs.inserted))
+	for _, fn := range c.subs.inserted {
+		fns = append(fns, fn)
+	}
+	c.subs.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(key, value)
+	}
+}
+
+func (c *cacheCore[K, V]) fireEviction(key K, value V, reason EvictionReason) {
+	if c.onEvicted != nil {
+		c.onEvicted(key, value, reason)
+	}
+
+	// See fireInsertion: snapshot then fire outside the lock so a subscriber can
+	// safely unsubscribe itself from within its own callback.
+	c.subs.mu.RLock()
+	fns := make([]func(key K, value V, reason EvictionReason), 0, len(c.subs.evicted))
+	for _, fn := range c.subs.evicted {
+		fns = append(fns, fn)
+	}
+	c.subs.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(key, value, reason)
+	}
+}
@@ -0,0 +1,36 @@
+package cache
+
+// expirationQueue is a container/heap min-heap of entries ordered by expiresAt, used
+// to find the next entry due to expire in O(log n) instead of scanning the whole map.
+// Only entries with hasExpiry==true are ever pushed onto it; an entry's heapIndex is
+// -1 whenever it isn't a heap member, which deleteLocked uses to know whether it
+// needs to heap.Remove the entry as well as delete it from items/lru.
+type expirationQueue[K comparable, V any] []*entry[K, V]
+
+func (q expirationQueue[K, V]) Len() int { return len(q) }
+
+func (q expirationQueue[K, V]) Less(i, j int) bool {
+	return q[i].expiresAt.Before(q[j].expiresAt)
+}
+
+func (q expirationQueue[K, V]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].heapIndex = i
+	q[j].heapIndex = j
+}
+
+func (q *expirationQueue[K, V]) Push(x any) {
+	e := x.(*entry[K, V])
+	e.heapIndex = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *expirationQueue[K, V]) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.heapIndex = -1
+	*q = old[:n-1]
+	return e
+}
@@ -2,27 +2,57 @@ package cache
 
 import "time"
 
-// expiryLoop periodically scans and removes expired entries.
+// expiryLoop is timer-driven off expQueue's head instead of a fixed ticker: it
+// sleeps until the earliest-expiring entry is due, reaps everything due by then, and
+// re-arms for the new head. Set signals timerCh whenever an insert/update could have
+// moved the head earlier; every wake recomputes the real head under the lock, so a
+// stale or early signal self-corrects instead of misbehaving.
 //
-// Why a ticker-based full scan?
-//   - It's easy to reason about (correctness-first)
-//   - It avoids per-entry goroutines/timers (which are expensive and hard to own)
-//   - It demonstrates real-world tradeoffs: predictable simplicity vs O(n) scans
-func (c *Cache) expiryLoop() {
+// Compared to the old fixed-ticker full scan, this makes expiry work O(log n) per
+// reaped entry instead of O(n) per tick, and it stops waking up entirely once there's
+// nothing left to expire — a cache full of non-expiring keys no longer pays for
+// wasted ticks.
+func (c *cacheCore[K, V]) expiryLoop() {
 	defer c.wg.Done()
+	defer close(c.loopExited)
 
-	ticker := time.NewTicker(c.cleanupEvery)
-	defer ticker.Stop()
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
-		case now := <-ticker.C:
+
+		case d := <-c.timerCh:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(d)
+
+		case <-timer.C:
 			c.mu.Lock()
-			// If Close raced with the ticker, still safe: Close cancels ctx, notifies loop.
-			c.deleteExpiredLocked(now)
+			// If Close raced with the timer, still safe: Close cancels ctx, notifies loop.
+			c.popExpiredLocked(time.Now())
+			pending := c.takePendingLocked()
+			var next time.Duration
+			hasNext := len(c.expQueue) > 0
+			if hasNext {
+				next = time.Until(c.expQueue[0].expiresAt)
+			}
 			c.mu.Unlock()
+
+			// Fire outside the lock so an observer can safely re-enter the cache.
+			c.firePending(pending)
+			if hasNext {
+				timer.Reset(next)
+			}
 		}
 	}
 }